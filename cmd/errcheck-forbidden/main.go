@@ -0,0 +1,30 @@
+// Command errcheck-forbidden fails if any non-test file in this module
+// imports the stdlib "errors" package instead of
+// github.com/NicholeGit/sugar/errors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/NicholeGit/sugar/internal/forbidimports"
+)
+
+func main() {
+	root := flag.String("root", ".", "module root to scan")
+	flag.Parse()
+
+	violations, err := forbidimports.Check(*root, forbidimports.ForbiddenStdErrors, forbidimports.StdErrorsAllowlist)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	for _, v := range violations {
+		fmt.Fprintln(os.Stderr, v.String())
+	}
+	if len(violations) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Code is a stable, machine-readable error identifier. It packs a service
+// scope into the top 8 bits, a category (input/db/auth/system/etc.) into
+// the next 8 bits, and a detail id into the low 16 bits. scope and
+// category must therefore each fit in 8 bits - see RegisterCode.
+type Code uint32
+
+const (
+	codeScopeShift    = 24
+	codeCategoryShift = 16
+	codeScopeMask     = 0xFF
+	codeCategoryMask  = 0xFF
+	codeDetailMask    = 0xFFFF
+)
+
+func newCode(scope, category, detail uint16) Code {
+	return Code(
+		uint32(scope&codeScopeMask)<<codeScopeShift |
+			uint32(category&codeCategoryMask)<<codeCategoryShift |
+			uint32(detail&codeDetailMask),
+	)
+}
+
+func (c Code) decode() (scope, category, detail uint16) {
+	v := uint32(c)
+	scope = uint16((v >> codeScopeShift) & codeScopeMask)
+	category = uint16((v >> codeCategoryShift) & codeCategoryMask)
+	detail = uint16(v & codeDetailMask)
+	return
+}
+
+// codeInfo is what RegisterCode associates with a Code.
+type codeInfo struct {
+	kind       Kind
+	defaultMsg string
+}
+
+var (
+	codeRegistryMu sync.RWMutex
+	codeRegistry   = map[Code]codeInfo{}
+)
+
+// RegisterCode registers a Code built from scope/category/detail, mapped
+// to a human Kind and a default message. Pass the returned Code to
+// E(...)/Wrap(...) to populate kind/message automatically when they
+// aren't otherwise given.
+//
+// scope and category are only given 8 bits each in the encoded Code, so
+// both must be <= 0xFF (detail uses the full 16 bits); RegisterCode
+// panics otherwise rather than silently truncating and colliding with an
+// unrelated scope/category.
+func RegisterCode(scope, category, detail uint16, kind Kind, defaultMsg string) Code {
+	if scope > codeScopeMask {
+		panic(fmt.Sprintf("errors.RegisterCode: scope %d overflows the 8 bits available (max %d)", scope, codeScopeMask))
+	}
+	if category > codeCategoryMask {
+		panic(fmt.Sprintf("errors.RegisterCode: category %d overflows the 8 bits available (max %d)", category, codeCategoryMask))
+	}
+
+	c := newCode(scope, category, detail)
+
+	codeRegistryMu.Lock()
+	codeRegistry[c] = codeInfo{kind: kind, defaultMsg: defaultMsg}
+	codeRegistryMu.Unlock()
+
+	return c
+}
+
+func lookupCode(c Code) (codeInfo, bool) {
+	codeRegistryMu.RLock()
+	info, ok := codeRegistry[c]
+	codeRegistryMu.RUnlock()
+	return info, ok
+}
+
+// GetCode returns the Code attached to err, walking the wrap chain
+// (including through a *MultiError's wrapped errors). It returns 0 if no
+// error in the chain carries a Code.
+func GetCode(err error) Code {
+	if e, ok := err.(*Error); ok && e.code != 0 {
+		return e.code
+	}
+
+	for _, child := range unwrapChildren(err) {
+		if code := GetCode(child); code != 0 {
+			return code
+		}
+	}
+
+	return 0
+}
+
+// CodeOf decodes the Code attached to err (see GetCode) into its
+// scope/category/detail components.
+func CodeOf(err error) (scope, category, detail uint16) {
+	return GetCode(err).decode()
+}
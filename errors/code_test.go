@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterCode_fillsKindAndMessage(t *testing.T) {
+	code := RegisterCode(1, 2, 42, KindNotExist, "user not found")
+
+	err := E(Operation("GetUser"), code)
+	assert.Equal(t, KindNotExist, GetKind(err))
+	assert.Equal(t, "user not found", GetMessage(err))
+	assert.Equal(t, code, GetCode(err))
+
+	scope, category, detail := CodeOf(err)
+	assert.Equal(t, uint16(1), scope)
+	assert.Equal(t, uint16(2), category)
+	assert.Equal(t, uint16(42), detail)
+}
+
+func TestRegisterCode_explicitOverridesWin(t *testing.T) {
+	code := RegisterCode(1, 2, 43, KindNotExist, "user not found")
+
+	err := E(Operation("GetUser"), code, KindInternal, "custom message")
+	assert.Equal(t, KindInternal, GetKind(err))
+	assert.Equal(t, "custom message", GetMessage(err))
+}
+
+func TestRegisterCode_overflowPanics(t *testing.T) {
+	t.Run("scope", func(t *testing.T) {
+		assert.Panics(t, func() { RegisterCode(257, 0, 0, KindInternal, "boom") })
+	})
+
+	t.Run("category", func(t *testing.T) {
+		assert.Panics(t, func() { RegisterCode(0, 257, 0, KindInternal, "boom") })
+	})
+
+	t.Run("in range doesn't collide", func(t *testing.T) {
+		c1 := RegisterCode(1, 0, 0, KindInternal, "first")
+		c2 := RegisterCode(2, 0, 0, KindNotExist, "second")
+		assert.NotEqual(t, c1, c2)
+	})
+}
+
+func TestGetCode(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		err := E(Operation("GetUser"), "boom")
+		assert.Equal(t, Code(0), GetCode(err))
+	})
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		code := RegisterCode(3, 4, 5, KindInternal, "internal error")
+		inner := E(Operation("GetUser"), code)
+		outer := E(Operation("HandleGetUser"), inner)
+		assert.Equal(t, code, GetCode(outer))
+	})
+
+	t.Run("not an *Error", func(t *testing.T) {
+		assert.Equal(t, Code(0), GetCode(ErrTest))
+	})
+}
@@ -3,16 +3,33 @@ package errors
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"regexp"
 	"runtime"
 	"strings"
 )
 
+// maxStackDepth bounds how many call frames are captured per error. It
+// mirrors the depth github.com/pkg/errors uses, which is deep enough for
+// any realistic call chain without wasting memory on every error.
+const maxStackDepth = 32
+
 type Operation string
 
 type Kind string
 
+// A small set of Kinds that are common enough across services to ship as
+// part of the core package, so callers (and subpackages like
+// errors/transport) have a stable, discoverable vocabulary to key off of
+// instead of inventing their own string literals. Services are free to
+// define additional Kinds of their own.
+const (
+	KindInternal        Kind = "INTERNAL"         // Internal error or inconsistency.
+	KindNotExist        Kind = "NOT_EXIST"        // Item does not exist.
+	KindInvalidArgument Kind = "INVALID_ARGUMENT" // Invalid argument for this type of item.
+)
+
 // Separator is the string used to separate nested errors
 const Separator = ":"
 
@@ -44,6 +61,49 @@ type Error struct {
 
 	// `Location` contain the error happened information. (e.g. filename, linenumber)
 	location *Location
+
+	// `stack` holds the raw program counters captured when the error was
+	// created, resolved lazily by StackTrace().
+	stack []uintptr
+
+	// `fields` holds structured context (e.g. request/user ids) attached
+	// via WithField. It survives wrapping and is readable with GetFields.
+	fields map[string]any
+
+	// `retryability` classifies whether retrying the failed operation is
+	// worth it. See Retryability, IsRetryable, IsPermanent, RequeueAfter.
+	retryability Retryability
+
+	// `code` is the stable, machine-readable identifier registered via
+	// RegisterCode. See Code, GetCode, CodeOf.
+	code Code
+}
+
+// Field is a key/value pair of structured context. It is built with
+// WithField and passed to E or Wrap the same way Kind and Operation are.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// WithField builds a Field that attaches structured context (e.g. a
+// request id or user id) to an error created by E or Wrap. Fields survive
+// wrapping and can be read back with GetFields.
+func WithField(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Frame is a single call-stack entry captured when an error was created.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String renders a frame in the style of github.com/pkg/errors:
+// `function\n\tfile:line`.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s\n\t%s:%d", f.Function, f.File, f.Line)
 }
 
 // E builds an error value from its arguments.
@@ -99,6 +159,15 @@ func eWithSkip(skip int, args ...interface{}) error {
 			// Make a copy
 			c := *arg
 			e.cause = &c
+		case Field:
+			if e.fields == nil {
+				e.fields = make(map[string]any)
+			}
+			e.fields[arg.Key] = arg.Value
+		case Retryability:
+			e.retryability = arg
+		case Code:
+			e.code = arg
 		case error:
 			e.cause = arg
 		default:
@@ -115,14 +184,38 @@ func eWithSkip(skip int, args ...interface{}) error {
 		lineNumber: line,
 	}
 
+	// Capture the full call stack alongside the single Location above, so
+	// callers that want more than one frame can get it via StackTrace().
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+1, pcs[:])
+	e.stack = pcs[:n]
+
 	// If `hasOp` is false, it indicates that use function name by `runtime.Caller`.
 	if !hasOp {
 		funcName := runtime.FuncForPC(pc).Name()
 		e.op = genOp(funcName)
 	}
 
-	// deduplication
-	if cause, ok := e.cause.(*Error); ok && cause.op == e.op {
+	// If a registered Code was given, fill in any kind/message it wasn't
+	// asked to override explicitly.
+	if e.code != 0 {
+		if info, ok := lookupCode(e.code); ok {
+			if e.kind == "" {
+				e.kind = info.kind
+			}
+			if e.message == "" {
+				e.message = info.defaultMsg
+			}
+		}
+	}
+
+	// deduplication: collapse `e` into its cause when this call only
+	// re-stated the same Operation, so we don't stack two frames for the
+	// same logical step. Skip the collapse if this call actually attached
+	// new state of its own (fields, retryability or a code) - otherwise
+	// that state would silently vanish along with `e`.
+	newStateAttached := len(e.fields) > 0 || e.retryability.kind != retryUnspecified || e.code != 0
+	if cause, ok := e.cause.(*Error); ok && cause.op == e.op && !newStateAttached {
 		return cause
 	}
 
@@ -144,6 +237,69 @@ func genOp(funcName string) Operation {
 // Unwrap provides compatibility for Go 1.13 error chains.
 func (w *Error) Unwrap() error { return w.cause }
 
+// StackTrace returns the call stack captured when the error was created,
+// innermost frame (the caller of E, Wrap or New) first. It returns nil if
+// no stack was captured.
+func (e *Error) StackTrace() []Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := make([]Frame, 0, len(e.stack))
+	cf := runtime.CallersFrames(e.stack)
+	for {
+		f, more := cf.Next()
+		frames = append(frames, Frame{
+			Function: f.Function,
+			File:     f.File,
+			Line:     f.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// GetStackTrace walks the wrapped chain and returns the deepest available
+// call stack, since the innermost error is usually the one closest to
+// where things actually went wrong.
+func GetStackTrace(err error) []Frame {
+	for _, child := range unwrapChildren(err) {
+		if deeper := GetStackTrace(child); deeper != nil {
+			return deeper
+		}
+	}
+
+	if e, ok := err.(*Error); ok {
+		return e.StackTrace()
+	}
+
+	return nil
+}
+
+// Format implements fmt.Formatter. `%v` and `%s` behave like Error(); `%+v`
+// additionally appends the deepest stack trace in the wrap chain (see
+// GetStackTrace), file:line:function per frame, in the style of
+// github.com/pkg/errors.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, e.Error())
+			for _, f := range GetStackTrace(e) {
+				fmt.Fprintf(s, "\n%s:%d: %s", f.File, f.Line, f.Function)
+			}
+			return
+		}
+		io.WriteString(s, e.Error())
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
 // Error returns the string representation of the error message.
 // Note: Refer to the unit tests for more detailed output.
 func (e *Error) Error() string {
@@ -192,34 +348,74 @@ func GetOps(e *Error) []Operation {
 	return res
 }
 
-func GetKind(err error) Kind {
-	e, ok := err.(*Error)
-	if !ok {
-		return ""
+// unwrapChildren returns the errors directly wrapped by err, via either
+// the single-cause `Unwrap() error` contract (*Error) or the Go 1.20
+// multi-cause `Unwrap() []error` contract (*MultiError). This lets the
+// chain-walking accessors below (GetKind, GetMessage, GetFields, ...)
+// look inside a *MultiError the same way they already look inside a
+// wrapped *Error.
+func unwrapChildren(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); cause != nil {
+			return []error{cause}
+		}
 	}
+	return nil
+}
 
-	if e.kind != "" {
+func GetKind(err error) Kind {
+	if e, ok := err.(*Error); ok && e.kind != "" {
 		return e.kind
 	}
 
-	return GetKind(e.cause)
+	for _, child := range unwrapChildren(err) {
+		if kind := GetKind(child); kind != "" {
+			return kind
+		}
+	}
+
+	return ""
 }
 
 // GetMessage returns the first human-readable message of the error, if available.
 func GetMessage(err error) string {
-	e, ok := err.(*Error)
-	if !ok {
-		return ""
-	}
-	if e.message != "" {
+	if e, ok := err.(*Error); ok && e.message != "" {
 		return e.message
 	}
-	if e.cause != nil {
-		return GetMessage(e.cause)
+
+	for _, child := range unwrapChildren(err) {
+		if msg := GetMessage(child); msg != "" {
+			return msg
+		}
 	}
+
 	return ""
 }
 
+// GetFields walks the wrapped chain and returns all structured context
+// attached via WithField. Fields from outer errors take precedence over
+// fields of the same key attached further down the chain.
+func GetFields(err error) map[string]any {
+	fields := make(map[string]any)
+	collectFields(err, fields)
+	return fields
+}
+
+func collectFields(err error, out map[string]any) {
+	for _, child := range unwrapChildren(err) {
+		collectFields(child, out)
+	}
+
+	if e, ok := err.(*Error); ok {
+		for k, v := range e.fields {
+			out[k] = v
+		}
+	}
+}
+
 // Notes: `Is` be replaced by `Match` since v1.0.3
 
 // `Match` reports whether err is an *Error of the given Kind.
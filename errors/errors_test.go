@@ -9,13 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-const (
-	KindInternal        Kind = "INTERNAL"         // Internal error or inconsistency.
-	KindNotExist        Kind = "NOT_EXIST"        // Item does not exist.
-	KindInvalidArgument Kind = "INVALID_ARGUMENT" // Invalid argument for this type of item.
-)
-
-// Line number is #20.
+// funcForAutoGenerateOpTest's body must stay on line 16, since
+// TestError's location-string case below asserts on
+// "errors_test.go#16" literally.
 func funcForAutoGenerateOpTest() error {
 	return E(KindInvalidArgument, "invalid arguments")
 }
@@ -31,45 +27,45 @@ func TestError_Error(t *testing.T) {
 	}{
 		"simple": {
 			E(Operation("DelUser"), "user joe not found"),
-			"[DelUser] (errors_test.go#33) user joe not found",
+			"[DelUser] (errors_test.go#29) user joe not found",
 		},
 		"simple with kind with Msg": {
 			E(Operation("DelUser"), KindNotExist, "user joe not found"),
-			"[DelUser] <NOT_EXIST> (errors_test.go#37) user joe not found",
+			"[DelUser] <NOT_EXIST> (errors_test.go#33) user joe not found",
 		},
 		"simple with kind without Msg": {
 			E(Operation("DelUser"), KindNotExist),
-			"[DelUser] <NOT_EXIST> (errors_test.go#41)",
+			"[DelUser] <NOT_EXIST> (errors_test.go#37)",
 		},
 		"simple with auto Op and location without Msg": {
 			funcForAutoGenerateOpTest(),
-			"[errors.funcForAutoGenerateOpTest] <INVALID_ARGUMENT> (errors_test.go#20) invalid arguments",
+			"[errors.funcForAutoGenerateOpTest] <INVALID_ARGUMENT> (errors_test.go#16) invalid arguments",
 		},
 		"wrap external error with print Msg": {
 			E(Operation("DelUser"), KindNotExist, "user joe not found", ErrTest),
-			"[DelUser] <NOT_EXIST> (errors_test.go#49) user joe not found a test error",
+			"[DelUser] <NOT_EXIST> (errors_test.go#45) user joe not found a test error",
 		},
 		"wrap external error": {
 			E(Operation("DelUser"), KindNotExist, ErrTest),
-			"[DelUser] <NOT_EXIST> (errors_test.go#53) a test error",
+			"[DelUser] <NOT_EXIST> (errors_test.go#49) a test error",
 		},
 		"wrap *Error": {
 			E(
 				Operation("HandleDelUser"),
 				E(Operation("DelUser"), KindNotExist, "user joe not found"),
 			),
-			"[HandleDelUser] (errors_test.go#57): [DelUser] <NOT_EXIST> (errors_test.go#59) user joe not found",
+			"[HandleDelUser] (errors_test.go#53): [DelUser] <NOT_EXIST> (errors_test.go#55) user joe not found",
 		},
 		"wrap *Error and external error": {
 			E(
 				Operation("HandleDelUser"), "user joe not found",
 				E(Operation("DelUser"), KindNotExist, ErrTest),
 			),
-			"[HandleDelUser] (errors_test.go#64) user joe not found: [DelUser] <NOT_EXIST> (errors_test.go#66) a test error",
+			"[HandleDelUser] (errors_test.go#60) user joe not found: [DelUser] <NOT_EXIST> (errors_test.go#62) a test error",
 		},
 		"wrap errors.New": {
 			E(Operation("DelUser"), KindNotExist, errors.New("user joe not found")),
-			"[DelUser] <NOT_EXIST> (errors_test.go#71) user joe not found",
+			"[DelUser] <NOT_EXIST> (errors_test.go#67) user joe not found",
 		},
 	}
 
@@ -97,7 +93,7 @@ func TestE(t *testing.T) {
 				cause:   nil,
 				location: &Location{
 					filename:   "errors_test.go",
-					lineNumber: 138,
+					lineNumber: 134,
 				},
 			},
 		},
@@ -110,7 +106,7 @@ func TestE(t *testing.T) {
 				cause:   ErrTest,
 				location: &Location{
 					filename:   "errors_test.go",
-					lineNumber: 138,
+					lineNumber: 134,
 				},
 			},
 		},
@@ -121,7 +117,7 @@ func TestE(t *testing.T) {
 				op:      Operation("GetUser"),
 				location: &Location{
 					filename:   "errors_test.go",
-					lineNumber: 138,
+					lineNumber: 134,
 				},
 			},
 		},
@@ -135,7 +131,7 @@ func TestE(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, tc.wantError, E(tc.givenOp, tc.givenKind, tc.givenMsg, tc.givenCause))
+			assert.Equal(t, tc.wantError, stripStack(E(tc.givenOp, tc.givenKind, tc.givenMsg, tc.givenCause)))
 		})
 	}
 
@@ -155,6 +151,22 @@ func TestE(t *testing.T) {
 		}
 		f1()
 	})
+
+	t.Run("dedup doesn't drop new state attached in the same call", func(t *testing.T) {
+		inner := E(Operation("DoThing"), "boom")
+
+		t.Run("retryability and fields", func(t *testing.T) {
+			outer := E(Operation("DoThing"), inner, Retryable, WithField("attempt", 2))
+			assert.True(t, IsRetryable(outer))
+			assert.Equal(t, map[string]any{"attempt": 2}, GetFields(outer))
+		})
+
+		t.Run("code", func(t *testing.T) {
+			code := RegisterCode(7, 7, 7, KindInternal, "boom")
+			outer := E(Operation("DoThing"), inner, code)
+			assert.Equal(t, code, GetCode(outer))
+		})
+	})
 }
 
 func TestWrap(t *testing.T) {
@@ -178,7 +190,7 @@ func TestWrap(t *testing.T) {
 				cause:   ErrTest,
 				location: &Location{
 					filename:   "errors_test.go",
-					lineNumber: 189,
+					lineNumber: 201,
 				},
 			},
 		},
@@ -186,7 +198,7 @@ func TestWrap(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, tc.wantError, Wrap(tc.givenCause, tc.givenOp, tc.givenKind, tc.givenMsg))
+			assert.Equal(t, tc.wantError, stripStack(Wrap(tc.givenCause, tc.givenOp, tc.givenKind, tc.givenMsg)))
 		})
 	}
 }
@@ -364,7 +376,7 @@ func TestNew(t *testing.T) {
 				op:      "TestNew.func1",
 				location: &Location{
 					filename:   "errors_test.go",
-					lineNumber: 375,
+					lineNumber: 387,
 				},
 			},
 		},
@@ -372,11 +384,20 @@ func TestNew(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, tc.wantError, New(tc.givenText))
+			assert.Equal(t, tc.wantError, stripStack(New(tc.givenText)))
 		})
 	}
 }
 
+// stripStack clears the captured call stack so tests can compare *Error
+// values by their other fields without pinning exact program counters.
+func stripStack(err error) error {
+	if e, ok := err.(*Error); ok {
+		e.stack = nil
+	}
+	return err
+}
+
 func TestIs(t *testing.T) {
 	tests := map[string]struct {
 		givenErr  error
@@ -0,0 +1,21 @@
+package errors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NicholeGit/sugar/internal/forbidimports"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestForbiddenImports guards against accidentally importing the stdlib
+// "errors" package instead of this one. stderrors.go is the one
+// legitimate exception: it's the shim that proxies Is/As/Unwrap to it.
+func TestForbiddenImports(t *testing.T) {
+	root, err := filepath.Abs("..")
+	assert.NoError(t, err)
+
+	violations, err := forbidimports.Check(root, forbidimports.ForbiddenStdErrors, forbidimports.StdErrorsAllowlist)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+}
@@ -0,0 +1,114 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+)
+
+// MultiError collects zero or more errors into a single error value. It
+// implements the Go 1.20 multi-unwrap contract (`Unwrap() []error`), so
+// errors.Is/errors.As (see stderrors.go) can match against any of the
+// errors it wraps.
+type MultiError struct {
+	errors []error
+}
+
+// Append collects the non-nil errors among err and errs into a single
+// error value. Nested *MultiError values are flattened in. If every
+// argument is nil, Append returns nil.
+func Append(err error, errs ...error) error {
+	m := &MultiError{}
+	m.add(err)
+	for _, e := range errs {
+		m.add(e)
+	}
+	return m.ErrorOrNil()
+}
+
+func (m *MultiError) add(err error) {
+	if err == nil {
+		return
+	}
+	if other, ok := err.(*MultiError); ok {
+		m.errors = append(m.errors, other.errors...)
+		return
+	}
+	m.errors = append(m.errors, err)
+}
+
+// Error joins the message of every collected error, space-separated and
+// wrapped in brackets.
+func (m *MultiError) Error() string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	for i, err := range m.errors {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(err.Error())
+	}
+	buf.WriteByte(']')
+
+	return buf.String()
+}
+
+// Unwrap returns the collected errors, satisfying the Go 1.20 multi-error
+// unwrap contract used by errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	return m.errors
+}
+
+// Errors returns the individual errors that were collected, in the order
+// they were added.
+func (m *MultiError) Errors() []error {
+	return m.errors
+}
+
+// ErrorOrNil returns nil if m has collected no errors, and m otherwise.
+// This lets Append return a plain nil `error` when nothing failed.
+func (m *MultiError) ErrorOrNil() error {
+	if len(m.errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+// MarshalJSON renders m as a JSON array of its collected errors, reusing
+// marshalCause so each error keeps its own structured form (recursively,
+// for a nested *MultiError) instead of being flattened to a string.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	parts := make([]json.RawMessage, len(m.errors))
+	for i, err := range m.errors {
+		parts[i] = marshalCause(err)
+	}
+	return json.Marshal(parts)
+}
+
+// LogValue implements slog.LogValuer, rendering m as a group of indexed
+// error attributes - reusing (*Error).LogValue via slog's automatic
+// LogValuer resolution - so a pool failure logs as structured fields the
+// same way a single *Error does.
+func (m *MultiError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(m.errors))
+	for i, err := range m.errors {
+		attrs[i] = slog.Any(strconv.Itoa(i), err)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Flatten collapses any nested *MultiError values within m into a single
+// flat *MultiError.
+func (m *MultiError) Flatten() *MultiError {
+	flat := &MultiError{}
+	for _, err := range m.errors {
+		if other, ok := err.(*MultiError); ok {
+			flat.errors = append(flat.errors, other.Flatten().errors...)
+		} else {
+			flat.errors = append(flat.errors, err)
+		}
+	}
+	return flat
+}
@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"encoding/json"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,3 +16,77 @@ func TestAppend(t *testing.T) {
 	assert.Contains(t, s, "err1")
 	assert.Contains(t, s, "err2")
 }
+
+func TestAppend_allNil(t *testing.T) {
+	assert.Nil(t, Append(nil, nil))
+}
+
+func TestAppend_flattensNested(t *testing.T) {
+	inner := Append(E("err1"), E("err2"))
+	mErr := Append(inner, E("err3")).(*MultiError)
+	assert.Len(t, mErr.Errors(), 3)
+}
+
+func TestMultiError_ErrorOrNil(t *testing.T) {
+	assert.Nil(t, (&MultiError{}).ErrorOrNil())
+
+	mErr := Append(E("err1")).(*MultiError)
+	assert.Equal(t, mErr, mErr.ErrorOrNil())
+}
+
+func TestMultiError_Flatten(t *testing.T) {
+	nested := &MultiError{errors: []error{
+		E("err1"),
+		&MultiError{errors: []error{E("err2"), E("err3")}},
+	}}
+
+	flat := nested.Flatten()
+	assert.Len(t, flat.Errors(), 3)
+}
+
+func TestMultiError_IsAs(t *testing.T) {
+	mErr := Append(ErrTest, E("err2"))
+	assert.True(t, Is(mErr, ErrTest))
+
+	var target *Error
+	assert.True(t, As(mErr, &target))
+}
+
+func TestMultiError_chainWalkingAccessors(t *testing.T) {
+	mErr := Append(
+		E(Operation("DelUser"), KindNotExist, Permanent, "bad", WithField("user_id", "joe")),
+		New("other"),
+	)
+
+	assert.Equal(t, KindNotExist, GetKind(mErr))
+	assert.Equal(t, "bad", GetMessage(mErr))
+	assert.Equal(t, map[string]any{"user_id": "joe"}, GetFields(mErr))
+	assert.True(t, IsPermanent(mErr))
+	assert.False(t, IsRetryable(mErr))
+
+	code := RegisterCode(9, 9, 9, KindInternal, "boom")
+	withCode := Append(New("other"), E(Operation("Op"), code))
+	assert.Equal(t, code, GetCode(withCode))
+}
+
+func TestMultiError_MarshalJSON(t *testing.T) {
+	mErr := Append(E(Operation("Op1"), "err1"), New("err2"))
+
+	b, err := json.Marshal(mErr)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "{}", string(b))
+
+	var got []map[string]any
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Len(t, got, 2)
+	assert.Equal(t, "Op1", got[0]["op"])
+	assert.Equal(t, "err1", got[0]["message"])
+}
+
+func TestMultiError_LogValue(t *testing.T) {
+	mErr := Append(E(Operation("Op1"), "err1"), New("err2")).(*MultiError)
+
+	attrs := AsAttrs(mErr)
+	assert.Len(t, attrs, 2)
+	assert.Equal(t, slog.KindGroup, attrs[0].Value.Resolve().Kind())
+}
@@ -0,0 +1,79 @@
+package errors
+
+import "time"
+
+// retryKind distinguishes the retry semantics carried by a Retryability
+// value.
+type retryKind int
+
+const (
+	retryUnspecified retryKind = iota
+	retryPermanent
+	retryRetryable
+	retryRequeue
+)
+
+// Retryability classifies whether an operation that produced an error is
+// worth retrying. It is settable via E(...)/Wrap(...) the same way Kind
+// and Operation are.
+type Retryability struct {
+	kind  retryKind
+	after time.Duration
+}
+
+var (
+	// Permanent marks an error as unrecoverable: retrying it is pointless.
+	Permanent = Retryability{kind: retryPermanent}
+
+	// Retryable marks an error as transient: the caller may retry
+	// immediately.
+	Retryable = Retryability{kind: retryRetryable}
+)
+
+// Requeue marks an error as transient, but one that should only be
+// retried after the given delay has elapsed. This mirrors the
+// controller-style requeue-after pattern.
+func Requeue(after time.Duration) Retryability {
+	return Retryability{kind: retryRequeue, after: after}
+}
+
+// IsRetryable reports whether err (or any error in its wrap chain) is
+// classified as Retryable or Requeue.
+func IsRetryable(err error) bool {
+	r, ok := retryabilityOf(err)
+	return ok && (r.kind == retryRetryable || r.kind == retryRequeue)
+}
+
+// IsPermanent reports whether err (or any error in its wrap chain) is
+// classified as Permanent.
+func IsPermanent(err error) bool {
+	r, ok := retryabilityOf(err)
+	return ok && r.kind == retryPermanent
+}
+
+// RequeueAfter reports the delay attached by Requeue, if err (or any
+// error in its wrap chain) carries one.
+func RequeueAfter(err error) (time.Duration, bool) {
+	r, ok := retryabilityOf(err)
+	if !ok || r.kind != retryRequeue {
+		return 0, false
+	}
+	return r.after, true
+}
+
+// retryabilityOf walks the wrap chain (including through a *MultiError's
+// wrapped errors) for the first explicitly set Retryability, the same way
+// GetKind walks the chain for Kind.
+func retryabilityOf(err error) (Retryability, bool) {
+	if e, ok := err.(*Error); ok && e.retryability.kind != retryUnspecified {
+		return e.retryability, true
+	}
+
+	for _, child := range unwrapChildren(err) {
+		if r, ok := retryabilityOf(child); ok {
+			return r, true
+		}
+	}
+
+	return Retryability{}, false
+}
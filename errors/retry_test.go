@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryability(t *testing.T) {
+	t.Run("permanent", func(t *testing.T) {
+		err := E(Operation("DelUser"), Permanent)
+		assert.True(t, IsPermanent(err))
+		assert.False(t, IsRetryable(err))
+	})
+
+	t.Run("retryable", func(t *testing.T) {
+		err := E(Operation("DelUser"), Retryable)
+		assert.True(t, IsRetryable(err))
+		assert.False(t, IsPermanent(err))
+	})
+
+	t.Run("requeue", func(t *testing.T) {
+		err := E(Operation("DelUser"), Requeue(5*time.Second))
+		assert.True(t, IsRetryable(err))
+
+		after, ok := RequeueAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, after)
+	})
+
+	t.Run("unclassified", func(t *testing.T) {
+		err := E(Operation("DelUser"), "boom")
+		assert.False(t, IsRetryable(err))
+		assert.False(t, IsPermanent(err))
+		_, ok := RequeueAfter(err)
+		assert.False(t, ok)
+	})
+
+	t.Run("classification survives wrapping", func(t *testing.T) {
+		inner := E(Operation("DelUser"), Permanent)
+		outer := E(Operation("HandleDelUser"), inner)
+		assert.True(t, IsPermanent(outer))
+	})
+
+	t.Run("not an *Error", func(t *testing.T) {
+		assert.False(t, IsRetryable(ErrTest))
+		assert.False(t, IsPermanent(ErrTest))
+	})
+}
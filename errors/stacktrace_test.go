@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_StackTrace(t *testing.T) {
+	err := E(Operation("DelUser"), "user joe not found").(*Error)
+
+	frames := err.StackTrace()
+	assert.NotEmpty(t, frames)
+	assert.Contains(t, frames[0].Function, "TestError_StackTrace")
+	assert.True(t, strings.HasSuffix(frames[0].File, "stacktrace_test.go"))
+}
+
+func TestGetStackTrace(t *testing.T) {
+	t.Run("not an *Error", func(t *testing.T) {
+		assert.Nil(t, GetStackTrace(fmt.Errorf("plain error")))
+	})
+
+	t.Run("uses the deepest wrapped error's stack", func(t *testing.T) {
+		inner := E(Operation("DelUser"), "user joe not found")
+		outer := E(Operation("HandleDelUser"), inner).(*Error)
+
+		frames := GetStackTrace(outer)
+		assert.NotEmpty(t, frames)
+		assert.Contains(t, frames[0].Function, "TestGetStackTrace")
+	})
+
+	t.Run("looks inside a *MultiError", func(t *testing.T) {
+		mErr := Append(E(Operation("DelUser"), "user joe not found"), New("other"))
+
+		frames := GetStackTrace(mErr)
+		assert.NotEmpty(t, frames)
+		assert.Contains(t, frames[0].Function, "TestGetStackTrace")
+	})
+}
+
+func TestError_Format(t *testing.T) {
+	err := E(Operation("DelUser"), "user joe not found").(*Error)
+
+	t.Run("%v and %s match Error()", func(t *testing.T) {
+		assert.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+		assert.Equal(t, err.Error(), fmt.Sprintf("%s", err))
+	})
+
+	t.Run("%+v appends the stack trace", func(t *testing.T) {
+		out := fmt.Sprintf("%+v", err)
+		assert.True(t, strings.HasPrefix(out, err.Error()))
+		assert.Contains(t, out, "stacktrace_test.go")
+		assert.Contains(t, out, "TestError_Format")
+	})
+}
@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// errorJSON is the wire representation produced by (*Error).MarshalJSON.
+type errorJSON struct {
+	Op       string          `json:"op,omitempty"`
+	Kind     string          `json:"kind,omitempty"`
+	Message  string          `json:"message,omitempty"`
+	Location string          `json:"location,omitempty"`
+	Stack    []string        `json:"stack,omitempty"`
+	Fields   map[string]any  `json:"fields,omitempty"`
+	Cause    json.RawMessage `json:"cause,omitempty"`
+}
+
+// MarshalJSON renders the error as a structured object suitable for JSON
+// logs, with `op`, `kind`, `message`, `location`, `fields` and (when
+// captured) `stack`. The wrapped cause, if any, is embedded recursively.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	ej := errorJSON{
+		Op:      string(e.op),
+		Kind:    string(e.kind),
+		Message: e.message,
+		Fields:  e.fields,
+	}
+
+	if e.location != nil {
+		ej.Location = e.location.String()
+	}
+
+	if frames := e.StackTrace(); len(frames) > 0 {
+		ej.Stack = make([]string, len(frames))
+		for i, f := range frames {
+			ej.Stack[i] = fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Function)
+		}
+	}
+
+	if e.cause != nil {
+		ej.Cause = marshalCause(e.cause)
+	}
+
+	return json.Marshal(ej)
+}
+
+// marshalCause renders a wrapped cause as JSON: recursively via its own
+// MarshalJSON when it's an *Error (or otherwise implements json.Marshaler),
+// or as its message string otherwise.
+func marshalCause(cause error) json.RawMessage {
+	if m, ok := cause.(json.Marshaler); ok {
+		if b, err := m.MarshalJSON(); err == nil {
+			return b
+		}
+	}
+	b, err := json.Marshal(cause.Error())
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// LogValue implements slog.LogValuer so errors land in structured logs as
+// a group of `op`/`kind`/`message`/`location`/`stack`/`cause` attributes,
+// plus one attribute per WithField key, instead of a single opaque string.
+func (e *Error) LogValue() slog.Value {
+	var attrs []slog.Attr
+
+	if e.op != "" {
+		attrs = append(attrs, slog.String("op", string(e.op)))
+	}
+	if e.kind != "" {
+		attrs = append(attrs, slog.String("kind", string(e.kind)))
+	}
+	if e.message != "" {
+		attrs = append(attrs, slog.String("message", e.message))
+	}
+	if e.location != nil {
+		attrs = append(attrs, slog.String("location", e.location.String()))
+	}
+	if frames := e.StackTrace(); len(frames) > 0 {
+		lines := make([]string, len(frames))
+		for i, f := range frames {
+			lines[i] = fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Function)
+		}
+		attrs = append(attrs, slog.Any("stack", lines))
+	}
+	for k, v := range e.fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	if e.cause != nil {
+		attrs = append(attrs, slog.Any("cause", e.cause))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// AsAttrs flattens err into slog.Attr values suitable for
+// `logger.LogAttrs(ctx, level, msg, errors.AsAttrs(err)...)`. Errors that
+// don't implement slog.LogValuer (see (*Error).LogValue and
+// (*MultiError).LogValue) fall back to a single `error` attribute.
+func AsAttrs(err error) []slog.Attr {
+	if err == nil {
+		return nil
+	}
+
+	lv, ok := err.(slog.LogValuer)
+	if !ok {
+		return []slog.Attr{slog.String("error", err.Error())}
+	}
+
+	return lv.LogValue().Group()
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_MarshalJSON(t *testing.T) {
+	err := E(Operation("DelUser"), KindNotExist, "user joe not found", WithField("user_id", "joe"))
+
+	b, marshalErr := json.Marshal(err)
+	assert.NoError(t, marshalErr)
+
+	var got map[string]any
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, "DelUser", got["op"])
+	assert.Equal(t, "NOT_EXIST", got["kind"])
+	assert.Equal(t, "user joe not found", got["message"])
+	assert.Equal(t, map[string]any{"user_id": "joe"}, got["fields"])
+}
+
+func TestError_MarshalJSON_withCause(t *testing.T) {
+	inner := E(Operation("DelUser"), KindNotExist, "user joe not found")
+	outer := E(Operation("HandleDelUser"), inner)
+
+	b, err := json.Marshal(outer)
+	assert.NoError(t, err)
+
+	var got map[string]any
+	assert.NoError(t, json.Unmarshal(b, &got))
+	cause, ok := got["cause"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "DelUser", cause["op"])
+}
+
+func TestError_LogValue(t *testing.T) {
+	err := E(Operation("DelUser"), KindNotExist, "user joe not found", WithField("user_id", "joe")).(*Error)
+
+	attrs := AsAttrs(err)
+
+	byKey := make(map[string]slog.Value, len(attrs))
+	for _, a := range attrs {
+		byKey[a.Key] = a.Value
+	}
+	assert.Equal(t, "DelUser", byKey["op"].String())
+	assert.Equal(t, "NOT_EXIST", byKey["kind"].String())
+	assert.Equal(t, "user joe not found", byKey["message"].String())
+	assert.Equal(t, "joe", byKey["user_id"].Any())
+}
+
+func TestAsAttrs_nonError(t *testing.T) {
+	attrs := AsAttrs(ErrTest)
+	assert.Len(t, attrs, 1)
+	assert.Equal(t, "error", attrs[0].Key)
+}
+
+func TestWithField_and_GetFields(t *testing.T) {
+	t.Run("single level", func(t *testing.T) {
+		err := E(Operation("DelUser"), WithField("user_id", "joe"), WithField("attempt", 1))
+		assert.Equal(t, map[string]any{"user_id": "joe", "attempt": 1}, GetFields(err))
+	})
+
+	t.Run("survives wrapping, outer wins on conflict", func(t *testing.T) {
+		inner := E(Operation("DelUser"), WithField("user_id", "joe"))
+		outer := E(Operation("HandleDelUser"), inner, WithField("user_id", "override"), WithField("request_id", "req-1"))
+		assert.Equal(t, map[string]any{"user_id": "override", "request_id": "req-1"}, GetFields(outer))
+	})
+
+	t.Run("not an *Error", func(t *testing.T) {
+		assert.Equal(t, map[string]any{}, GetFields(ErrTest))
+	})
+}
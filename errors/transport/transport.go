@@ -0,0 +1,189 @@
+// Package transport converts between *errors.Error and the two dominant
+// Go RPC ecosystems, gRPC and HTTP, without pulling either dependency
+// into the core errors package.
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/NicholeGit/sugar/errors"
+)
+
+var (
+	kindHTTPMu sync.RWMutex
+	kindHTTP   = map[errors.Kind]int{
+		errors.KindNotExist:        http.StatusNotFound,
+		errors.KindInvalidArgument: http.StatusBadRequest,
+		errors.KindInternal:        http.StatusInternalServerError,
+	}
+
+	kindGRPCMu sync.RWMutex
+	kindGRPC   = map[errors.Kind]codes.Code{
+		errors.KindNotExist:        codes.NotFound,
+		errors.KindInvalidArgument: codes.InvalidArgument,
+		errors.KindInternal:        codes.Internal,
+	}
+)
+
+// RegisterKindHTTP registers (or overrides) the HTTP status code that
+// HTTPStatusCode/WriteHTTPError return for errors of the given Kind.
+func RegisterKindHTTP(kind errors.Kind, code int) {
+	kindHTTPMu.Lock()
+	kindHTTP[kind] = code
+	kindHTTPMu.Unlock()
+}
+
+// RegisterKindGRPC registers (or overrides) the gRPC code that
+// ToGRPCStatus returns for errors of the given Kind.
+func RegisterKindGRPC(kind errors.Kind, code codes.Code) {
+	kindGRPCMu.Lock()
+	kindGRPC[kind] = code
+	kindGRPCMu.Unlock()
+}
+
+// HTTPStatusCode picks an HTTP status code for err based on its Kind,
+// defaulting to 500 for an empty or unregistered Kind. A nil err maps to
+// 200.
+func HTTPStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	kindHTTPMu.RLock()
+	code, ok := kindHTTP[errors.GetKind(err)]
+	kindHTTPMu.RUnlock()
+	if ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteHTTPError writes err to w as a JSON body with the status code from
+// HTTPStatusCode. If err implements json.Marshaler (as *errors.Error and
+// *errors.MultiError do), its own structured form is written; otherwise a
+// minimal {"message": ...} envelope is used.
+func WriteHTTPError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatusCode(err))
+
+	if _, ok := err.(json.Marshaler); ok {
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": err.Error()})
+}
+
+// grpcCodeForKind resolves the registered gRPC code for kind, falling
+// back to codes.Unknown.
+func grpcCodeForKind(kind errors.Kind) codes.Code {
+	kindGRPCMu.RLock()
+	code, ok := kindGRPC[kind]
+	kindGRPCMu.RUnlock()
+	if ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// kindForGRPCCode is the inverse of grpcCodeForKind, used by
+// FromGRPCStatus to recover a Kind when the wire status didn't carry one
+// explicitly in its details.
+func kindForGRPCCode(code codes.Code) (errors.Kind, bool) {
+	kindGRPCMu.RLock()
+	defer kindGRPCMu.RUnlock()
+	for kind, c := range kindGRPC {
+		if c == code {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// ToGRPCStatus converts err into a *status.Status. Operation, Kind and
+// any WithField context are round-tripped via status.WithDetails so that
+// FromGRPCStatus can reconstruct an equivalent *errors.Error.
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	kind := errors.GetKind(err)
+	st := status.New(grpcCodeForKind(kind), errors.GetMessage(err))
+
+	detail := map[string]interface{}{
+		"op":   string(opOf(err)),
+		"kind": string(kind),
+	}
+	if fields := errors.GetFields(err); len(fields) > 0 {
+		detail["fields"] = fields
+	}
+
+	s, buildErr := structpb.NewStruct(detail)
+	if buildErr != nil {
+		return st
+	}
+	if withDetails, detailsErr := st.WithDetails(s); detailsErr == nil {
+		return withDetails
+	}
+	return st
+}
+
+// opOf returns the outermost Operation recorded on err, if err is a
+// *errors.Error.
+func opOf(err error) errors.Operation {
+	e, ok := err.(*errors.Error)
+	if !ok {
+		return ""
+	}
+	ops := errors.GetOps(e)
+	if len(ops) == 0 {
+		return ""
+	}
+	return ops[0]
+}
+
+// FromGRPCStatus converts a *status.Status back into an error, restoring
+// the Operation, Kind, message and fields that ToGRPCStatus attached as
+// details. It returns nil for a nil status or one with code OK.
+func FromGRPCStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	args := []interface{}{st.Message()}
+
+	var haveKind bool
+	for _, d := range st.Details() {
+		s, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		m := s.AsMap()
+		if op, ok := m["op"].(string); ok && op != "" {
+			args = append(args, errors.Operation(op))
+		}
+		if kind, ok := m["kind"].(string); ok && kind != "" {
+			args = append(args, errors.Kind(kind))
+			haveKind = true
+		}
+		if fields, ok := m["fields"].(map[string]interface{}); ok {
+			for k, v := range fields {
+				args = append(args, errors.WithField(k, v))
+			}
+		}
+	}
+
+	if !haveKind {
+		if kind, ok := kindForGRPCCode(st.Code()); ok {
+			args = append(args, kind)
+		}
+	}
+
+	return errors.E(args...)
+}
@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/NicholeGit/sugar/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatusCode(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, HTTPStatusCode(nil))
+	})
+
+	t.Run("registered kind", func(t *testing.T) {
+		err := errors.E(errors.Operation("GetUser"), errors.Kind("NOT_EXIST"), "user joe not found")
+		assert.Equal(t, http.StatusNotFound, HTTPStatusCode(err))
+	})
+
+	t.Run("unregistered kind defaults to 500", func(t *testing.T) {
+		err := errors.E(errors.Operation("GetUser"), errors.Kind("SOMETHING_ELSE"))
+		assert.Equal(t, http.StatusInternalServerError, HTTPStatusCode(err))
+	})
+
+	t.Run("custom registration", func(t *testing.T) {
+		RegisterKindHTTP(errors.Kind("TEAPOT"), http.StatusTeapot)
+		err := errors.E(errors.Operation("Brew"), errors.Kind("TEAPOT"))
+		assert.Equal(t, http.StatusTeapot, HTTPStatusCode(err))
+	})
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	err := errors.E(errors.Operation("GetUser"), errors.Kind("NOT_EXIST"), "user joe not found")
+
+	rec := httptest.NewRecorder()
+	WriteHTTPError(rec, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "user joe not found")
+}
+
+func TestGRPCStatusRoundTrip(t *testing.T) {
+	orig := errors.E(
+		errors.Operation("GetUser"),
+		errors.Kind("NOT_EXIST"),
+		"user joe not found",
+		errors.WithField("user_id", "joe"),
+	)
+
+	st := ToGRPCStatus(orig)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "user joe not found", st.Message())
+
+	roundTripped := FromGRPCStatus(st)
+	assert.Equal(t, errors.Kind("NOT_EXIST"), errors.GetKind(roundTripped))
+	assert.Equal(t, "user joe not found", errors.GetMessage(roundTripped))
+	assert.Equal(t, "joe", errors.GetFields(roundTripped)["user_id"])
+}
+
+func TestFromGRPCStatus_nilAndOK(t *testing.T) {
+	assert.Nil(t, FromGRPCStatus(nil))
+	assert.Nil(t, FromGRPCStatus(ToGRPCStatus(nil)))
+}
+
+func TestRegisterKindGRPC(t *testing.T) {
+	RegisterKindGRPC(errors.Kind("CONFLICT"), codes.AlreadyExists)
+	err := errors.E(errors.Operation("CreateUser"), errors.Kind("CONFLICT"))
+	assert.Equal(t, codes.AlreadyExists, ToGRPCStatus(err).Code())
+}
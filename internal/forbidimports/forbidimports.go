@@ -0,0 +1,106 @@
+// Package forbidimports walks a Go module's own source tree and reports
+// any non-test file that imports a forbidden package. It backs the
+// cmd/errcheck-forbidden tool and the errors.TestForbiddenImports test
+// helper, which both use it to keep the stdlib "errors" package out of
+// this module in favor of github.com/NicholeGit/sugar/errors.
+package forbidimports
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Violation is a single disallowed import found in a source file.
+type Violation struct {
+	File string
+	Line int
+	Path string
+}
+
+// String renders a violation as a `file:line: message` diagnostic.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s:%d: imports forbidden package %q", v.File, v.Line, v.Path)
+}
+
+// Check walks every non-test .go file under root and reports any that
+// import one of forbidden, skipping files listed in allowlist. Both
+// allowlist entries and the returned Violation.File are paths relative
+// to root, using forward slashes.
+//
+// Check walks the filesystem directly rather than resolving the build's
+// actual import graph (the way `go list ./...` would), so it doesn't
+// honor build constraints - a file excluded by a //go:build tag or a
+// GOOS/GOARCH filename suffix is still scanned. That's fine for this
+// module's current layout, which has no such files, but should be
+// tightened (e.g. by driving the walk off `go/build`) before a package
+// with build-tagged files relies on it.
+func Check(root string, forbidden []string, allowlist []string) ([]Violation, error) {
+	isForbidden := make(map[string]bool, len(forbidden))
+	for _, p := range forbidden {
+		isForbidden[p] = true
+	}
+
+	allowed := make(map[string]bool, len(allowlist))
+	for _, a := range allowlist {
+		allowed[filepath.ToSlash(a)] = true
+	}
+
+	var violations []Violation
+	fset := token.NewFileSet()
+
+	walkErr := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if name := info.Name(); path != root && (name == ".git" || name == "vendor" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if allowed[rel] {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, imp := range f.Imports {
+			importPath := strings.Trim(imp.Path.Value, `"`)
+			if isForbidden[importPath] {
+				violations = append(violations, Violation{
+					File: rel,
+					Line: fset.Position(imp.Pos()).Line,
+					Path: importPath,
+				})
+			}
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations, nil
+}
@@ -0,0 +1,48 @@
+package forbidimports
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "bad.go", `package foo
+
+import "errors"
+
+var _ = errors.New
+`)
+	writeFile(t, dir, "good.go", `package foo
+
+import "github.com/NicholeGit/sugar/errors"
+
+var _ = errors.New
+`)
+	writeFile(t, dir, "allowed.go", `package foo
+
+import "errors"
+
+var _ = errors.New
+`)
+	writeFile(t, dir, "bad_test.go", `package foo
+
+import "errors"
+
+var _ = errors.New
+`)
+
+	violations, err := Check(dir, []string{"errors"}, []string{"allowed.go"})
+	assert.NoError(t, err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "bad.go", violations[0].File)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
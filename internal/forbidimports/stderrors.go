@@ -0,0 +1,16 @@
+package forbidimports
+
+// ForbiddenStdErrors and StdErrorsAllowlist configure this module's one
+// current use of Check: keeping the stdlib "errors" package out of
+// github.com/NicholeGit/sugar in favor of github.com/NicholeGit/sugar/errors.
+// Both cmd/errcheck-forbidden and errors.TestForbiddenImports read these
+// instead of each declaring their own copy, so they can't drift apart.
+var (
+	ForbiddenStdErrors = []string{"errors"}
+
+	// StdErrorsAllowlist holds the files permitted to import stdlib
+	// "errors" directly. errors/stderrors.go is the shim that proxies
+	// Is/As/Unwrap to it; everything else in the module should go
+	// through github.com/NicholeGit/sugar/errors.
+	StdErrorsAllowlist = []string{"errors/stderrors.go"}
+)
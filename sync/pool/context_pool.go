@@ -0,0 +1,50 @@
+package pool
+
+import (
+	"context"
+
+	"github.com/NicholeGit/sugar/errors"
+)
+
+// ContextPool is a pool that runs tasks accepting a context.Context. The
+// pool's context is canceled as soon as a task returns an error, unless
+// that error is classified as errors.Retryable (or errors.Requeue), in
+// which case the error is still collected by Wait() but the pool keeps
+// running the remaining tasks.
+//
+// A new ContextPool should be created using `New().WithErrors().WithContext(ctx)`.
+type ContextPool struct {
+	errorPool ErrorPool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Go submits a task to the pool. If the task returns an error that isn't
+// classified as errors.Retryable, the pool's context is canceled
+// immediately - this always happens for an errors.Permanent error, and
+// also for any error with no retry classification at all.
+func (p *ContextPool) Go(f func(ctx context.Context) error) {
+	p.errorPool.Go(func() error {
+		err := f(p.ctx)
+		if err != nil && !errors.IsRetryable(err) {
+			p.cancel()
+		}
+		return err
+	})
+}
+
+// Wait cleans up any spawned goroutines, cancels the pool's context, and
+// returns any errors from tasks.
+func (p *ContextPool) Wait() error {
+	err := p.errorPool.Wait()
+	p.cancel()
+	return err
+}
+
+// WithFirstError configures the pool to only return the first error
+// returned by a task. By default, Wait() will return a combined error.
+func (p *ContextPool) WithFirstError() *ContextPool {
+	p.errorPool.WithFirstError()
+	return p
+}
@@ -0,0 +1,51 @@
+package pool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NicholeGit/sugar/errors"
+)
+
+func TestContextPool_cancelsOnPermanentError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := New().WithErrors().WithContext(ctx)
+	p.Go(func(ctx context.Context) error {
+		return errors.E(errors.Permanent, "boom")
+	})
+
+	// errorPool.Wait (as opposed to p.Wait) blocks until the task above has
+	// run without itself canceling the context, so we can tell whether Go
+	// canceled it because of the Permanent error.
+	if err := p.errorPool.Wait(); err == nil {
+		t.Fatalf("Wait() returned nil error, want the Permanent error")
+	}
+
+	select {
+	case <-p.ctx.Done():
+	default:
+		t.Fatalf("pool context was not canceled after a Permanent error")
+	}
+}
+
+func TestContextPool_doesNotCancelOnRetryableError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := New().WithErrors().WithContext(ctx)
+	p.Go(func(ctx context.Context) error {
+		return errors.E(errors.Retryable, "try again")
+	})
+
+	if err := p.errorPool.Wait(); err == nil {
+		t.Fatalf("Wait() returned nil error, want the Retryable error")
+	}
+
+	select {
+	case <-p.ctx.Done():
+		t.Fatalf("pool context was canceled after a Retryable error")
+	default:
+	}
+}
@@ -28,7 +28,9 @@ func (p *ErrorPool) Go(f func() error) {
 }
 
 // Wait cleans up any spawned goroutines, propagating any panics and
-// returning any errors from tasks.
+// returning any errors from tasks. Unless WithFirstError was set, a
+// non-nil result is an *errors.MultiError holding every task's error, so
+// individual task errors can still be inspected with errors.As/errors.Is.
 func (p *ErrorPool) Wait() error {
 	p.pool.Wait()
 	return p.errs
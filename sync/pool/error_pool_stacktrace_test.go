@@ -0,0 +1,24 @@
+package pool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NicholeGit/sugar/errors"
+)
+
+func TestErrorPool_GetStackTrace(t *testing.T) {
+	p := New().WithErrors()
+	p.Go(func() error {
+		return errors.New("oh no!")
+	})
+	err := p.Wait()
+
+	frames := errors.GetStackTrace(err)
+	if len(frames) == 0 {
+		t.Fatalf("GetStackTrace returned no frames for an ErrorPool failure")
+	}
+	if !strings.Contains(frames[0].Function, "TestErrorPool_GetStackTrace") {
+		t.Fatalf("frames[0].Function = %q, want it to contain the calling test", frames[0].Function)
+	}
+}
@@ -0,0 +1,82 @@
+package pool
+
+import "sync"
+
+// New creates a new Pool.
+func New() *Pool {
+	return &Pool{}
+}
+
+// Pool is a pool of goroutines used to execute tasks concurrently.
+// Tasks may be added to the pool using Go(). Once all your tasks have
+// been submitted, you must call Wait() to block until all tasks are
+// completed and the goroutines created by the pool are shut down.
+//
+// The zero value of a Pool is usable, just like sync.WaitGroup. The
+// pool can be configured while it is idle, before any tasks are
+// submitted.
+type Pool struct {
+	wg sync.WaitGroup
+
+	maxGoroutines int
+	sem           chan struct{}
+	initOnce      sync.Once
+}
+
+func (p *Pool) init() {
+	p.initOnce.Do(func() {
+		if p.maxGoroutines > 0 {
+			p.sem = make(chan struct{}, p.maxGoroutines)
+		}
+	})
+}
+
+// Go submits a task for the pool to execute.
+func (p *Pool) Go(f func()) {
+	p.init()
+
+	if p.sem == nil {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			f()
+		}()
+		return
+	}
+
+	p.sem <- struct{}{}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.sem }()
+		f()
+	}()
+}
+
+// Wait blocks until all the tasks submitted to the pool have completed.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+// WithMaxGoroutines limits the number of goroutines in a pool.
+// Defaults to unlimited. Panics if n < 1.
+func (p *Pool) WithMaxGoroutines(n int) *Pool {
+	if n < 1 {
+		panic("max goroutines in a pool must be greater than zero")
+	}
+	p.maxGoroutines = n
+	return p
+}
+
+// WithErrors converts the pool to an ErrorPool for tasks that return an
+// error.
+func (p *Pool) WithErrors() *ErrorPool {
+	return &ErrorPool{pool: p.deref()}
+}
+
+// deref is a helper that creates a shallow copy of the pool with the same
+// settings. We don't want to just dereference the pointer because that
+// makes the copylock lint angry.
+func (p *Pool) deref() Pool {
+	return Pool{maxGoroutines: p.maxGoroutines}
+}
@@ -0,0 +1,48 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool_WithMaxGoroutines(t *testing.T) {
+	const (
+		maxGoroutines = 3
+		numTasks      = 20
+	)
+
+	p := New().WithMaxGoroutines(maxGoroutines)
+
+	var current, maxSeen int64
+	for i := 0; i < numTasks; i++ {
+		p.Go(func() {
+			n := atomic.AddInt64(&current, 1)
+			for {
+				seen := atomic.LoadInt64(&maxSeen)
+				if n <= seen || atomic.CompareAndSwapInt64(&maxSeen, seen, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&current, -1)
+		})
+	}
+	p.Wait()
+
+	if maxSeen > maxGoroutines {
+		t.Fatalf("observed %d concurrent goroutines, want at most %d", maxSeen, maxGoroutines)
+	}
+	if maxSeen < 2 {
+		t.Fatalf("observed only %d concurrent goroutine(s) across %d tasks, want to see some actual concurrency", maxSeen, numTasks)
+	}
+}
+
+func TestPool_WithMaxGoroutines_panicsOnNonPositive(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("WithMaxGoroutines(0) did not panic")
+		}
+	}()
+	New().WithMaxGoroutines(0)
+}
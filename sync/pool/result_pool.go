@@ -0,0 +1,44 @@
+package pool
+
+import "sync"
+
+// NewWithResults creates a new ResultPool for tasks that return a result
+// of type T.
+func NewWithResults[T any]() *ResultPool[T] {
+	return &ResultPool[T]{}
+}
+
+// ResultPool is a pool that runs tasks that return a value. Result order
+// is not guaranteed to match the order tasks were submitted in.
+//
+// A new ResultPool should be created using `NewWithResults[T]()`.
+type ResultPool[T any] struct {
+	pool Pool
+
+	mu      sync.Mutex
+	results []T
+}
+
+// Go submits a task to the pool.
+func (p *ResultPool[T]) Go(f func() T) {
+	p.pool.Go(func() {
+		res := f()
+		p.mu.Lock()
+		p.results = append(p.results, res)
+		p.mu.Unlock()
+	})
+}
+
+// Wait cleans up any spawned goroutines, returning the results from all
+// tasks.
+func (p *ResultPool[T]) Wait() []T {
+	p.pool.Wait()
+	return p.results
+}
+
+// WithMaxGoroutines limits the number of goroutines in a pool.
+// Defaults to unlimited. Panics if n < 1.
+func (p *ResultPool[T]) WithMaxGoroutines(n int) *ResultPool[T] {
+	p.pool.WithMaxGoroutines(n)
+	return p
+}